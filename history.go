@@ -0,0 +1,175 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/jakubfrieb/go-utils-compare/internal/jobdiff"
+)
+
+// runHistory implements the `history` subcommand: a version-by-version diff
+// across more than two cron manifest snapshots, similar in spirit to
+// `nomad job history -p`.
+func runHistory(args []string) {
+	fs := flag.NewFlagSet("history", flag.ExitOnError)
+	deltaOnly := fs.Bool("p", false, "Show only the delta between consecutive versions")
+	full := fs.Bool("full", false, "Dump the full cron job list of every version")
+	strictSchedule := fs.Bool("strict-schedule", false, "Compare schedules as raw strings instead of semantic cron equivalence")
+	fs.Parse(args)
+
+	if fs.NArg() < 1 {
+		log.Fatalf("Usage: %s history [-p] [-full] [--strict-schedule] <snapshot-dir> | <file1> <file2> [...]\n", os.Args[0])
+	}
+
+	versions, paths, err := resolveSnapshots(fs.Args())
+	if err != nil {
+		log.Fatalf("Error resolving snapshots: %v\n", err)
+	}
+	if len(paths) < 2 {
+		log.Fatalf("history requires at least 2 snapshots, got %d\n", len(paths))
+	}
+
+	configs := make([]*jobdiff.Config, len(paths))
+	for i, path := range paths {
+		cfg, err := jobdiff.ParseYAML(path)
+		if err != nil {
+			log.Fatalf("Error reading snapshot %s: %v\n", path, err)
+		}
+		configs[i] = cfg
+	}
+
+	opts := jobdiff.Options{StrictSchedule: *strictSchedule}
+
+	if *full {
+		for i, version := range versions {
+			renderVersionDump(version, configs[i])
+		}
+	}
+
+	history := jobdiff.CompareHistory(versions, configs, opts)
+
+	if *deltaOnly {
+		renderHistoryDelta(history)
+		return
+	}
+
+	renderHistoryMatrix(versions, configs)
+}
+
+// resolveSnapshots turns the history subcommand's positional args into an
+// ordered list of (version label, file path) pairs. A single directory
+// argument is expanded into its *.yaml/*.yml files sorted by name, which
+// works naturally for timestamped snapshot filenames; otherwise every
+// argument is treated as an individual snapshot file, in the given order.
+func resolveSnapshots(args []string) (versions []string, paths []string, err error) {
+	if len(args) == 1 {
+		info, statErr := os.Stat(args[0])
+		if statErr == nil && info.IsDir() {
+			entries, readErr := os.ReadDir(args[0])
+			if readErr != nil {
+				return nil, nil, readErr
+			}
+			var names []string
+			for _, entry := range entries {
+				if entry.IsDir() {
+					continue
+				}
+				ext := filepath.Ext(entry.Name())
+				if ext != ".yaml" && ext != ".yml" {
+					continue
+				}
+				names = append(names, entry.Name())
+			}
+			sort.Strings(names)
+			for _, name := range names {
+				versions = append(versions, versionLabel(name))
+				paths = append(paths, filepath.Join(args[0], name))
+			}
+			return versions, paths, nil
+		}
+	}
+
+	for _, path := range args {
+		versions = append(versions, versionLabel(filepath.Base(path)))
+		paths = append(paths, path)
+	}
+	return versions, paths, nil
+}
+
+func versionLabel(filename string) string {
+	return strings.TrimSuffix(strings.TrimSuffix(filename, ".yaml"), ".yml")
+}
+
+// renderVersionDump prints the full cron job listing for a single version,
+// as requested by -full.
+func renderVersionDump(version string, config *jobdiff.Config) {
+	fmt.Printf("Version: %s\n", version)
+	w := tabwriter.NewWriter(os.Stdout, 10, 8, 3, ' ', 0)
+	fmt.Fprintf(w, "%s\t%s\t%s\n", "Name", "Schedule", "Command")
+	for _, job := range config.CronJobs {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", job.Name, job.Schedule, job.Command)
+	}
+	w.Flush()
+	fmt.Println()
+}
+
+// renderHistoryDelta prints only the changes between each consecutive pair
+// of versions, as requested by -p.
+func renderHistoryDelta(history []jobdiff.HistoryDiff) {
+	for _, step := range history {
+		fmt.Printf("%s -> %s\n", step.FromVersion, step.ToVersion)
+		if len(step.Diffs) == 0 {
+			fmt.Println("  (no changes)")
+			continue
+		}
+		for _, diff := range step.Diffs {
+			fmt.Printf("  %s: %s\n", diff.CronName, diff.Type)
+		}
+	}
+}
+
+// renderHistoryMatrix lays out every version as a column and every cron job
+// as a row, showing each job's schedule and command side by side across the
+// full history.
+func renderHistoryMatrix(versions []string, configs []*jobdiff.Config) {
+	names := make(map[string]bool)
+	for _, cfg := range configs {
+		for _, job := range cfg.CronJobs {
+			names[job.Name] = true
+		}
+	}
+	var sortedNames []string
+	for name := range names {
+		sortedNames = append(sortedNames, name)
+	}
+	sort.Strings(sortedNames)
+
+	w := tabwriter.NewWriter(os.Stdout, 10, 8, 3, ' ', 0)
+	header := []string{"Cron Name"}
+	header = append(header, versions...)
+	fmt.Fprintln(w, strings.Join(header, "\t"))
+
+	for _, name := range sortedNames {
+		row := []string{name}
+		for _, cfg := range configs {
+			row = append(row, cellValue(cfg, name))
+		}
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+	w.Flush()
+}
+
+func cellValue(config *jobdiff.Config, name string) string {
+	for _, job := range config.CronJobs {
+		if job.Name == name {
+			return fmt.Sprintf("%s | %s", job.Schedule, job.Command)
+		}
+	}
+	return "-"
+}
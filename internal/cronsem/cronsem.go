@@ -0,0 +1,206 @@
+// Package cronsem compares standard five-field cron expressions for
+// semantic equivalence rather than textual equality, so that e.g.
+// "0 */2 * * *" and "0 0,2,4,6,8,10,12,14,16,18,20,22 * * *" are recognized
+// as firing at exactly the same times.
+package cronsem
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// fieldRange describes the valid bounds of a cron field.
+type fieldRange struct {
+	min, max int
+}
+
+var fieldRanges = [5]fieldRange{
+	{0, 59}, // minute
+	{0, 23}, // hour
+	{1, 31}, // day of month
+	{1, 12}, // month
+	{0, 6},  // day of week
+}
+
+// dowField is the index of the day-of-week field within fieldRanges, Canonical
+// and the parsed field list.
+const dowField = 4
+
+// aliases maps the non-standard descriptors to their five-field equivalent.
+var aliases = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// Canonical is the expanded set of allowed values for each of the five
+// standard cron fields: minute, hour, day-of-month, month, day-of-week.
+type Canonical [5]map[int]bool
+
+// Parse expands a cron expression into its canonical field sets.
+func Parse(expr string) (Canonical, error) {
+	var c Canonical
+
+	expr = strings.TrimSpace(expr)
+	if alias, ok := aliases[expr]; ok {
+		expr = alias
+	}
+
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return c, fmt.Errorf("cronsem: expected 5 fields, got %d in %q", len(fields), expr)
+	}
+
+	for i, field := range fields {
+		r := fieldRanges[i]
+		if i == dowField {
+			// Some cron dialects also accept 7 for Sunday alongside 0;
+			// widen the range here and fold 7 back onto 0 below.
+			r.max = 7
+		}
+
+		set, err := parseField(field, r)
+		if err != nil {
+			return c, fmt.Errorf("cronsem: field %d (%q): %w", i, field, err)
+		}
+		if i == dowField {
+			normalizeDayOfWeek(set)
+		}
+		c[i] = set
+	}
+	return c, nil
+}
+
+// normalizeDayOfWeek folds the non-standard Sunday alias 7 onto 0, so
+// expressions using either are recognized as equivalent.
+func normalizeDayOfWeek(set map[int]bool) {
+	if set[7] {
+		delete(set, 7)
+		set[0] = true
+	}
+}
+
+// Equal reports whether two canonical schedules allow exactly the same set
+// of values in every field.
+func (c Canonical) Equal(other Canonical) bool {
+	for i := range c {
+		if len(c[i]) != len(other[i]) {
+			return false
+		}
+		for v := range c[i] {
+			if !other[i][v] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// String renders the canonical schedule back into a five-field expression
+// with each field's allowed values sorted and comma-joined.
+func (c Canonical) String() string {
+	parts := make([]string, 5)
+	for i, set := range c {
+		values := make([]int, 0, len(set))
+		for v := range set {
+			values = append(values, v)
+		}
+		sortInts(values)
+
+		strs := make([]string, len(values))
+		for j, v := range values {
+			strs[j] = strconv.Itoa(v)
+		}
+		parts[i] = strings.Join(strs, ",")
+	}
+	return strings.Join(parts, " ")
+}
+
+// Equivalent reports whether two cron expressions fire at identical times,
+// i.e. whether their canonical field sets are identical.
+func Equivalent(a, b string) bool {
+	ca, err := Parse(a)
+	if err != nil {
+		return false
+	}
+	cb, err := Parse(b)
+	if err != nil {
+		return false
+	}
+	return ca.Equal(cb)
+}
+
+func parseField(field string, r fieldRange) (map[int]bool, error) {
+	set := make(map[int]bool)
+
+	for _, part := range strings.Split(field, ",") {
+		if part == "*" {
+			for v := r.min; v <= r.max; v++ {
+				set[v] = true
+			}
+			continue
+		}
+
+		step := 1
+		hasStep := false
+		rangePart := part
+		if idx := strings.Index(part, "/"); idx != -1 {
+			rangePart = part[:idx]
+			s, err := strconv.Atoi(part[idx+1:])
+			if err != nil || s <= 0 {
+				return nil, fmt.Errorf("invalid step in %q", part)
+			}
+			step = s
+			hasStep = true
+		}
+
+		lo, hi := r.min, r.max
+		if rangePart != "*" {
+			if idx := strings.Index(rangePart, "-"); idx != -1 {
+				var err error
+				lo, err = strconv.Atoi(rangePart[:idx])
+				if err != nil {
+					return nil, fmt.Errorf("invalid range start in %q", rangePart)
+				}
+				hi, err = strconv.Atoi(rangePart[idx+1:])
+				if err != nil {
+					return nil, fmt.Errorf("invalid range end in %q", rangePart)
+				}
+			} else {
+				v, err := strconv.Atoi(rangePart)
+				if err != nil {
+					return nil, fmt.Errorf("invalid value %q", rangePart)
+				}
+				lo = v
+				hi = v
+				// "N/step" with no explicit range end (e.g. "5/15") means
+				// "start at N, step, through the field's max", not just N.
+				if hasStep {
+					hi = r.max
+				}
+			}
+		}
+
+		for v := lo; v <= hi; v += step {
+			if v < r.min || v > r.max {
+				return nil, fmt.Errorf("value %d out of range [%d,%d]", v, r.min, r.max)
+			}
+			set[v] = true
+		}
+	}
+
+	return set, nil
+}
+
+func sortInts(values []int) {
+	for i := 1; i < len(values); i++ {
+		for j := i; j > 0 && values[j-1] > values[j]; j-- {
+			values[j-1], values[j] = values[j], values[j-1]
+		}
+	}
+}
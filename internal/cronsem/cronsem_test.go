@@ -0,0 +1,41 @@
+package cronsem
+
+import "testing"
+
+func TestEquivalent(t *testing.T) {
+	cases := []struct {
+		name string
+		a, b string
+		want bool
+	}{
+		{"identical", "0 0 * * *", "0 0 * * *", true},
+		{"step vs explicit list", "*/15 * * * *", "0,15,30,45 * * * *", true},
+		{"step with start vs explicit list", "5/15 * * * *", "5,20,35,50 * * * *", true},
+		{"step with start missing a value", "5/15 * * * *", "5,20,35 * * * *", false},
+		{"weekly alias vs sunday 0", "@weekly", "0 0 * * 0", true},
+		{"day-of-week 7 alias for sunday", "0 0 * * 0", "0 0 * * 7", true},
+		{"hourly alias", "@hourly", "0 * * * *", true},
+		{"genuinely different hour", "0 0 * * *", "0 1 * * *", false},
+		{"invalid expression", "not a cron", "0 0 * * *", false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := Equivalent(tc.a, tc.b); got != tc.want {
+				t.Errorf("Equivalent(%q, %q) = %v, want %v", tc.a, tc.b, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseRejectsOutOfRangeValue(t *testing.T) {
+	if _, err := Parse("60 0 * * *"); err == nil {
+		t.Error("Parse(\"60 0 * * *\") returned no error, want out-of-range error")
+	}
+}
+
+func TestParseRejectsWrongFieldCount(t *testing.T) {
+	if _, err := Parse("0 0 * *"); err == nil {
+		t.Error("Parse(\"0 0 * *\") returned no error, want field-count error")
+	}
+}
@@ -0,0 +1,45 @@
+package jobdiff
+
+// HistoryDiff describes the differences between two consecutive
+// configuration snapshots in a job-history comparison. Diffs reuses
+// JobDifference, with Values keyed by FromVersion and ToVersion.
+type HistoryDiff struct {
+	FromVersion string          `json:"from_version" yaml:"from_version"`
+	ToVersion   string          `json:"to_version" yaml:"to_version"`
+	Diffs       []JobDifference `json:"diffs" yaml:"diffs"`
+}
+
+// CompareHistory walks consecutive pairs of configs, labelled in order by
+// versions, and returns the differences between each pair.
+func CompareHistory(versions []string, configs []*Config, opts Options) []HistoryDiff {
+	var history []HistoryDiff
+	for i := 1; i < len(configs); i++ {
+		from, to := versions[i-1], versions[i]
+		diffs := FindDifferences(map[string]*Config{
+			from: configs[i-1],
+			to:   configs[i],
+		}, from, opts)
+		classifyAddedRemoved(diffs, from, to)
+		history = append(history, HistoryDiff{FromVersion: from, ToVersion: to, Diffs: diffs})
+	}
+	return history
+}
+
+// classifyAddedRemoved refines the generic existsInSomeType difference into
+// Added or Removed. Unlike the arbitrary-environment case FindDifferences
+// handles, a history step always compares exactly two versions in
+// chronological order, so direction is well-defined: missing from "to" means
+// the job was Removed, missing from "from" means it was Added.
+func classifyAddedRemoved(diffs []JobDifference, from, to string) {
+	for i := range diffs {
+		if diffs[i].Type != existsInSomeType {
+			continue
+		}
+		switch {
+		case diffs[i].Values[to] == "missing":
+			diffs[i].Type = "Removed"
+		case diffs[i].Values[from] == "missing":
+			diffs[i].Type = "Added"
+		}
+	}
+}
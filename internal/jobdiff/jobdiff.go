@@ -0,0 +1,278 @@
+// Package jobdiff holds the cron-job manifest model and comparison logic
+// shared by the CLI, the scheduler watcher, and pkg/compare.
+package jobdiff
+
+import (
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/jakubfrieb/go-utils-compare/internal/cronsem"
+)
+
+type CronJob struct {
+	Command  string `yaml:"command"`
+	Name     string `yaml:"name"`
+	Schedule string `yaml:"schedule"`
+}
+
+type Config struct {
+	CronJobs []CronJob `yaml:"cronjobs"`
+}
+
+// JobDifference describes a single discrepancy found for one cron job
+// across any number of environments.
+type JobDifference struct {
+	CronName string `json:"cron_name" yaml:"cron_name"`
+	Type     string `json:"type" yaml:"type"`
+
+	// Values holds every environment's raw value for the differing field
+	// (command, schedule, or "missing"), keyed by environment name.
+	Values map[string]string `json:"values,omitempty" yaml:"values,omitempty"`
+
+	// Canonical holds each environment's canonical cron form, populated only
+	// for schedule differences that are textual but not semantic.
+	Canonical map[string]string `json:"canonical,omitempty" yaml:"canonical,omitempty"`
+
+	// UnifiedDiffs holds, for each non-baseline environment whose multi-line
+	// command differs from the baseline's, a unified diff (see
+	// UnifiedCommandDiff) against the baseline.
+	UnifiedDiffs map[string]string `json:"unified_diffs,omitempty" yaml:"unified_diffs,omitempty"`
+}
+
+// existsInSomeType is the Type reported when a job is present in at least
+// one environment but not all of them, across an arbitrary number of named
+// environments with no inherent ordering. CompareHistory, which always
+// compares exactly two versions in chronological order, refines this into
+// Added/Removed.
+const existsInSomeType = "Exists in some environments but not others"
+
+// Options controls how FindDifferences compares configs.
+type Options struct {
+	// StrictSchedule disables semantic cron equivalence and falls back to
+	// raw string comparison of the Schedule field.
+	StrictSchedule bool
+}
+
+// ParseYAML reads and parses a cron manifest from disk.
+func ParseYAML(path string) (*Config, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var config Config
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+func createCronJobMap(cronJobs []CronJob) map[string]CronJob {
+	jobMap := make(map[string]CronJob)
+	for _, job := range cronJobs {
+		jobMap[job.Name] = job
+	}
+	return jobMap
+}
+
+// NormalizeCommand collapses multiple spaces so cosmetic whitespace changes
+// are not reported as differences.
+func NormalizeCommand(command string) string {
+	return strings.Join(strings.Fields(command), " ")
+}
+
+// FindDifferences compares the cron jobs defined across configs (keyed by
+// environment name) and returns the full list of differences between them.
+// baseline names the environment other environments are diffed against when
+// more than two environments diverge; if the job is missing from baseline,
+// the lexicographically first environment that has it is used instead.
+func FindDifferences(configs map[string]*Config, baseline string, opts Options) []JobDifference {
+	return CompareWith(configs, baseline, !opts.StrictSchedule, commandsEqual, rawEqual)
+}
+
+// CompareWith is the comparison engine underlying FindDifferences. It is
+// exported so callers that need non-default equality semantics (e.g.
+// pkg/compare, which gates on go-cmp options such as ignored fields) can
+// reuse the same job/command/schedule classification instead of
+// reimplementing it.
+//
+// commandEqual and scheduleEqual decide whether two environments' raw
+// Command/Schedule values should be treated as equal before schedule
+// canonicalization (controlled by canonicalSchedule) is considered.
+func CompareWith(configs map[string]*Config, baseline string, canonicalSchedule bool, commandEqual, scheduleEqual func(a, b string) bool) []JobDifference {
+	envs := sortedEnvNames(configs)
+
+	jobsByEnv := make(map[string]map[string]CronJob, len(configs))
+	names := make(map[string]bool)
+	for env, cfg := range configs {
+		jobs := createCronJobMap(cfg.CronJobs)
+		jobsByEnv[env] = jobs
+		for name := range jobs {
+			names[name] = true
+		}
+	}
+
+	var differences []JobDifference
+	for _, name := range sortedNames(names) {
+		differences = append(differences, jobDifferences(name, envs, jobsByEnv, baseline, canonicalSchedule, commandEqual, scheduleEqual)...)
+	}
+	return differences
+}
+
+func commandsEqual(a, b string) bool { return NormalizeCommand(a) == NormalizeCommand(b) }
+
+func rawEqual(a, b string) bool { return a == b }
+
+// jobDifferences computes every JobDifference for a single cron job name
+// across all environments.
+func jobDifferences(name string, envs []string, jobsByEnv map[string]map[string]CronJob, baseline string, canonicalSchedule bool, commandEqual, scheduleEqual func(a, b string) bool) []JobDifference {
+	present := make(map[string]CronJob)
+	var missing []string
+	for _, env := range envs {
+		if job, ok := jobsByEnv[env][name]; ok {
+			present[env] = job
+		} else {
+			missing = append(missing, env)
+		}
+	}
+
+	var differences []JobDifference
+	if len(missing) > 0 && len(present) > 0 {
+		values := make(map[string]string, len(envs))
+		for _, env := range envs {
+			if _, ok := present[env]; ok {
+				values[env] = "present"
+			} else {
+				values[env] = "missing"
+			}
+		}
+		differences = append(differences, JobDifference{CronName: name, Type: existsInSomeType, Values: values})
+	}
+
+	if len(present) < 2 {
+		return differences
+	}
+
+	reference := referenceEnv(envs, present, baseline)
+
+	if diff, ok := commandDifference(name, reference, present, commandEqual); ok {
+		differences = append(differences, diff)
+	}
+	if diff, ok := scheduleDifference(name, reference, present, canonicalSchedule, scheduleEqual); ok {
+		differences = append(differences, diff)
+	}
+
+	return differences
+}
+
+// referenceEnv picks the environment every other environment is diffed
+// against: baseline if it has the job, otherwise the first environment
+// (in sorted order) that does.
+func referenceEnv(envs []string, present map[string]CronJob, baseline string) string {
+	if _, ok := present[baseline]; ok {
+		return baseline
+	}
+	for _, env := range envs {
+		if _, ok := present[env]; ok {
+			return env
+		}
+	}
+	return ""
+}
+
+func commandDifference(name, reference string, present map[string]CronJob, commandEqual func(a, b string) bool) (JobDifference, bool) {
+	refCommand := present[reference].Command
+	diverges := false
+	for env, job := range present {
+		if env == reference {
+			continue
+		}
+		if !commandEqual(job.Command, refCommand) {
+			diverges = true
+			break
+		}
+	}
+	if !diverges {
+		return JobDifference{}, false
+	}
+
+	values := make(map[string]string, len(present))
+	var unifiedDiffs map[string]string
+	for env, job := range present {
+		values[env] = job.Command
+		if env != reference && !commandEqual(job.Command, refCommand) && (isMultiline(job.Command) || isMultiline(refCommand)) {
+			if unifiedDiffs == nil {
+				unifiedDiffs = make(map[string]string)
+			}
+			unifiedDiffs[env] = UnifiedCommandDiff(refCommand, job.Command)
+		}
+	}
+
+	return JobDifference{CronName: name, Type: "Command Difference", Values: values, UnifiedDiffs: unifiedDiffs}, true
+}
+
+func scheduleDifference(name, reference string, present map[string]CronJob, canonicalSchedule bool, scheduleEqual func(a, b string) bool) (JobDifference, bool) {
+	refSchedule := present[reference].Schedule
+	diverges := false
+	for env, job := range present {
+		if env != reference && !scheduleEqual(job.Schedule, refSchedule) {
+			diverges = true
+			break
+		}
+	}
+	if !diverges {
+		return JobDifference{}, false
+	}
+
+	values := make(map[string]string, len(present))
+	for env, job := range present {
+		values[env] = job.Schedule
+	}
+
+	if !canonicalSchedule {
+		return JobDifference{CronName: name, Type: "Schedule Difference", Values: values}, true
+	}
+
+	allEquivalent := true
+	for env, job := range present {
+		if env != reference && !cronsem.Equivalent(job.Schedule, refSchedule) {
+			allEquivalent = false
+			break
+		}
+	}
+	if !allEquivalent {
+		return JobDifference{CronName: name, Type: "Schedule Difference", Values: values}, true
+	}
+
+	canonical := make(map[string]string, len(present))
+	for env, job := range present {
+		if c, err := cronsem.Parse(job.Schedule); err == nil {
+			canonical[env] = c.String()
+		}
+	}
+	return JobDifference{CronName: name, Type: "Schedule Difference (textual only)", Values: values, Canonical: canonical}, true
+}
+
+func isMultiline(s string) bool {
+	return strings.Contains(s, "\n")
+}
+
+func sortedEnvNames(configs map[string]*Config) []string {
+	envs := make([]string, 0, len(configs))
+	for env := range configs {
+		envs = append(envs, env)
+	}
+	sort.Strings(envs)
+	return envs
+}
+
+func sortedNames(names map[string]bool) []string {
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+	return sorted
+}
@@ -0,0 +1,81 @@
+package jobdiff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// UnifiedCommandDiff renders a unified diff (in the style of `diff -u`)
+// between two commands split into lines: an "@@" hunk header followed by
+// unchanged lines (prefixed " "), removed lines (prefixed "-") and added
+// lines (prefixed "+"), computed from a minimal line-level edit script.
+func UnifiedCommandDiff(a, b string) string {
+	aLines := strings.Split(a, "\n")
+	bLines := strings.Split(b, "\n")
+
+	var out strings.Builder
+	fmt.Fprintf(&out, "--- a\n+++ b\n@@ -1,%d +1,%d @@\n", len(aLines), len(bLines))
+	for _, op := range diffLines(aLines, bLines) {
+		out.WriteByte(op.marker)
+		out.WriteString(op.text)
+		out.WriteByte('\n')
+	}
+	return out.String()
+}
+
+// lineOp is one line of a unified diff: unchanged (' '), removed ('-') or
+// added ('+').
+type lineOp struct {
+	marker byte
+	text   string
+}
+
+// diffLines computes a minimal edit script turning a into b using the
+// standard longest-common-subsequence diff algorithm, the same approach
+// `diff` itself uses to pick the lines a unified diff reports as unchanged.
+func diffLines(a, b []string) []lineOp {
+	n, m := len(a), len(b)
+
+	// lcs[i][j] is the length of the longest common subsequence of a[i:]
+	// and b[j:].
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			switch {
+			case a[i] == b[j]:
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			case lcs[i+1][j] >= lcs[i][j+1]:
+				lcs[i][j] = lcs[i+1][j]
+			default:
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var ops []lineOp
+	i, j := 0, 0
+	for i < n && j < m {
+		switch {
+		case a[i] == b[j]:
+			ops = append(ops, lineOp{' ', a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			ops = append(ops, lineOp{'-', a[i]})
+			i++
+		default:
+			ops = append(ops, lineOp{'+', b[j]})
+			j++
+		}
+	}
+	for ; i < n; i++ {
+		ops = append(ops, lineOp{'-', a[i]})
+	}
+	for ; j < m; j++ {
+		ops = append(ops, lineOp{'+', b[j]})
+	}
+	return ops
+}
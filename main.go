@@ -1,183 +1,261 @@
 package main
 
 import (
+	"encoding/csv"
 	"encoding/json"
 	"flag"
 	"fmt"
-	"io/ioutil"
 	"log"
 	"os"
+	"sort"
 	"strings"
 	"text/tabwriter"
 
+	"github.com/google/go-cmp/cmp"
 	"gopkg.in/yaml.v2"
+
+	"github.com/jakubfrieb/go-utils-compare/internal/jobdiff"
+	"github.com/jakubfrieb/go-utils-compare/pkg/compare"
 )
 
 // ANSI color codes
 const (
-	Reset       = "\033[0m"
-	Yellow      = "\033[33m"
-	Red         = "\033[31m"
-	LightBlue   = "\033[94m"
+	Reset     = "\033[0m"
+	Yellow    = "\033[33m"
+	Red       = "\033[31m"
+	LightBlue = "\033[94m"
+)
+
+// Supported values for the --output/-o flag.
+const (
+	OutputText  = "text"
+	OutputJSON  = "json"
+	OutputYAML  = "yaml"
+	OutputTable = "table"
+	OutputCSV   = "csv"
 )
 
-type CronJob struct {
-	Command  string `yaml:"command"`
-	Name     string `yaml:"name"`
-	Schedule string `yaml:"schedule"`
+// envFiles implements flag.Value for repeated --file env=path flags.
+type envFiles map[string]string
+
+func (f envFiles) String() string {
+	var parts []string
+	for env, path := range f {
+		parts = append(parts, env+"="+path)
+	}
+	return strings.Join(parts, ",")
 }
 
-type Config struct {
-	CronJobs []CronJob `yaml:"cronjobs"`
+func (f envFiles) Set(value string) error {
+	env, path, ok := strings.Cut(value, "=")
+	if !ok || env == "" || path == "" {
+		return fmt.Errorf("expected env=path, got %q", value)
+	}
+	f[env] = path
+	return nil
 }
 
-// JSON structure to hold differences
-type JobDifference struct {
-	CronName    string `json:"cron_name"`
-	Type        string `json:"type"`
-	Production  string `json:"production,omitempty"`
-	Development string `json:"development,omitempty"`
+func compareCommands(configs map[string]*jobdiff.Config, baseline, output string, opts jobdiff.Options, ignoreFields []string) {
+	cmpOpts := []cmp.Option{compare.NormalizeWhitespace()}
+	if len(ignoreFields) > 0 {
+		cmpOpts = append(cmpOpts, compare.IgnoreFields(ignoreFields...))
+	}
+
+	comparator := compare.New(cmpOpts...)
+	comparator.CanonicalSchedule = !opts.StrictSchedule
+	differences := comparator.Compare(configs, baseline)
+
+	envs := make([]string, 0, len(configs))
+	for env := range configs {
+		envs = append(envs, env)
+	}
+	sort.Strings(envs)
+
+	switch output {
+	case OutputJSON:
+		renderJSON(differences)
+	case OutputYAML:
+		renderYAML(differences)
+	case OutputTable:
+		renderTable(envs, differences)
+	case OutputCSV:
+		renderCSV(envs, differences)
+	default:
+		renderText(envs, baseline, differences)
+	}
 }
 
-func createCronJobMap(cronJobs []CronJob) map[string]CronJob {
-	jobMap := make(map[string]CronJob)
-	for _, job := range cronJobs {
-		jobMap[job.Name] = job
+func renderJSON(differences []jobdiff.JobDifference) {
+	jsonData, err := json.MarshalIndent(differences, "", "  ")
+	if err != nil {
+		log.Fatalf("Error marshalling to JSON: %v", err)
 	}
-	return jobMap
+	fmt.Println(string(jsonData))
 }
 
-// Helper function to normalize commands by collapsing multiple spaces
-func normalizeCommand(command string) string {
-	return strings.Join(strings.Fields(command), " ")
+func renderYAML(differences []jobdiff.JobDifference) {
+	yamlData, err := yaml.Marshal(differences)
+	if err != nil {
+		log.Fatalf("Error marshalling to YAML: %v", err)
+	}
+	fmt.Print(string(yamlData))
 }
 
-func compareCommands(prodFile, devFile string, prodConfig, devConfig *Config, jsonOutput bool) {
-	prodCronJobs := createCronJobMap(prodConfig.CronJobs)
-	devCronJobs := createCronJobMap(devConfig.CronJobs)
-
-	// List to hold differences in case of JSON output
-	var differences []JobDifference
-
-	if jsonOutput {
-		// Collect differences in JSON format
-		for name, prodJob := range prodCronJobs {
-			if devJob, exists := devCronJobs[name]; exists {
-				if normalizeCommand(prodJob.Command) != normalizeCommand(devJob.Command) {
-					differences = append(differences, JobDifference{
-						CronName:   name,
-						Type:       "Command Difference",
-						Production: prodJob.Command,
-						Development: devJob.Command,
-					})
-				}
-				if prodJob.Schedule != devJob.Schedule {
-					differences = append(differences, JobDifference{
-						CronName:   name,
-						Type:       "Schedule Difference",
-						Production: prodJob.Schedule,
-						Development: devJob.Schedule,
-					})
-				}
-			} else {
-				differences = append(differences, JobDifference{
-					CronName: name,
-					Type:     "Exists in production but not in development",
-				})
-			}
+// renderTable prints an uncolored, pipe-friendly aligned table with one
+// column per environment.
+func renderTable(envs []string, differences []jobdiff.JobDifference) {
+	w := tabwriter.NewWriter(os.Stdout, 10, 8, 3, ' ', 0)
+	header := append([]string{"Cron Name", "Type"}, envs...)
+	fmt.Fprintln(w, strings.Join(header, "\t"))
+	for _, diff := range differences {
+		row := []string{diff.CronName, diff.Type}
+		for _, env := range envs {
+			row = append(row, diff.Values[env])
 		}
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+	w.Flush()
+}
 
-		for name := range devCronJobs {
-			if _, exists := prodCronJobs[name]; !exists {
-				differences = append(differences, JobDifference{
-					CronName: name,
-					Type:     "Exists in development but not in production",
-				})
-			}
-		}
+func renderCSV(envs []string, differences []jobdiff.JobDifference) {
+	writer := csv.NewWriter(os.Stdout)
 
-		// Output as JSON
-		jsonData, err := json.MarshalIndent(differences, "", "  ")
-		if err != nil {
-			log.Fatalf("Error marshalling to JSON: %v", err)
+	header := append([]string{"cron_name", "type"}, envs...)
+	if err := writer.Write(header); err != nil {
+		log.Fatalf("Error writing CSV header: %v", err)
+	}
+	for _, diff := range differences {
+		row := []string{diff.CronName, diff.Type}
+		for _, env := range envs {
+			row = append(row, diff.Values[env])
 		}
-		fmt.Println(string(jsonData))
-
-	} else {
-		// Human-readable output
-		w := tabwriter.NewWriter(os.Stdout, 10, 8, 3, ' ', 0)
+		if err := writer.Write(row); err != nil {
+			log.Fatalf("Error writing CSV row: %v", err)
+		}
+	}
 
-		fmt.Printf("Comparing Cron Jobs:\n")
-		fmt.Printf("Production File: %s\n", prodFile)
-		fmt.Printf("Development File: %s\n", devFile)
-		fmt.Printf("\n")
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		log.Fatalf("Error flushing CSV: %v", err)
+	}
+}
 
-		fmt.Fprintf(w, "%-40s\t%-70s\n", "Cron Name", "Difference")
-		fmt.Fprintf(w, "%-40s\t%-70s\n", "---------", "----------")
+// renderText prints an aligned matrix with one column per environment,
+// highlighting cells that diverge from the baseline.
+func renderText(envs []string, baseline string, differences []jobdiff.JobDifference) {
+	w := tabwriter.NewWriter(os.Stdout, 10, 8, 3, ' ', 0)
 
-		for name, prodJob := range prodCronJobs {
-			var differences string
+	fmt.Printf("Comparing Cron Jobs across environments: %s\n", strings.Join(envs, ", "))
+	fmt.Printf("Baseline: %s\n\n", baseline)
 
-			if devJob, exists := devCronJobs[name]; exists {
-				if normalizeCommand(prodJob.Command) != normalizeCommand(devJob.Command) {
-					differences += fmt.Sprintf("%sCommand difference:\n  Production: %s\n  Development: %s%s\n", Red, prodJob.Command, devJob.Command, Reset)
-				}
-				if prodJob.Schedule != devJob.Schedule {
-					differences += fmt.Sprintf("%sSchedule difference:\n  Production: %s\n  Development: %s%s\n", Yellow, prodJob.Schedule, devJob.Schedule, Reset)
-				}
-			} else {
-				differences = fmt.Sprintf("%sExists in production but not in development%s", LightBlue, Reset)
-			}
+	header := append([]string{"Cron Name", "Type"}, envs...)
+	fmt.Fprintln(w, strings.Join(header, "\t"))
 
-			if differences != "" {
-				fmt.Fprintf(w, "%-40s\t%-70s\n", name, differences)
+	for _, diff := range differences {
+		row := []string{diff.CronName, colorForType(diff.Type) + diff.Type + Reset}
+		for _, env := range envs {
+			value := diff.Values[env]
+			if env != baseline && value != diff.Values[baseline] {
+				value = colorForType(diff.Type) + value + Reset
 			}
+			row = append(row, value)
 		}
+		fmt.Fprintln(w, strings.Join(row, "\t"))
 
-		for name := range devCronJobs {
-			if _, exists := prodCronJobs[name]; !exists {
-				fmt.Fprintf(w, "%-40s\t%-70s\n", name, fmt.Sprintf("%sExists in development but not in production%s", LightBlue, Reset))
+		for env, udiff := range diff.UnifiedDiffs {
+			fmt.Fprintf(w, "  %s vs %s:\n%s\n", baseline, env, udiff)
+		}
+		if len(diff.Canonical) > 0 {
+			for _, env := range envs {
+				if c, ok := diff.Canonical[env]; ok {
+					fmt.Fprintf(w, "  %s canonical: %s\n", env, c)
+				}
 			}
 		}
-
-		w.Flush()
 	}
+
+	w.Flush()
 }
 
-func parseYAML(path string) (*Config, error) {
-	data, err := ioutil.ReadFile(path)
-	if err != nil {
-		return nil, err
+func colorForType(diffType string) string {
+	switch diffType {
+	case "Command Difference":
+		return Red
+	case "Schedule Difference", "Schedule Difference (textual only)":
+		return Yellow
+	default:
+		return LightBlue
 	}
-	var config Config
-	err = yaml.Unmarshal(data, &config)
-	if err != nil {
-		return nil, err
+}
+
+func validOutput(output string) bool {
+	switch output {
+	case OutputText, OutputJSON, OutputYAML, OutputTable, OutputCSV:
+		return true
+	default:
+		return false
 	}
-	return &config, nil
 }
 
-func main() {
-	// Add --json flag to switch output to JSON format
-	jsonOutput := flag.Bool("json", false, "Output differences in JSON format")
-	flag.Parse()
+func runCompare(args []string) {
+	fs := flag.NewFlagSet("compare", flag.ExitOnError)
+	var output string
+	fs.StringVar(&output, "output", OutputText, "Output format: text, json, yaml, table, csv")
+	fs.StringVar(&output, "o", OutputText, "Shorthand for --output")
+	strictSchedule := fs.Bool("strict-schedule", false, "Compare schedules as raw strings instead of semantic cron equivalence")
+	ignoreFields := fs.String("ignore-fields", "", "Comma-separated CronJob fields to exclude from comparison (Name, Command, Schedule)")
+	baseline := fs.String("baseline", "", "Environment every other environment is diffed against (defaults to the first --file given)")
+	files := make(envFiles)
+	fs.Var(files, "file", "env=path, repeatable, e.g. --file prod=prod.yaml --file staging=stage.yaml")
+	fs.Parse(args)
+
+	if len(files) < 2 {
+		log.Fatalf("Usage: %s --file env1=path1 --file env2=path2 [--file env3=path3 ...] [--baseline env] [-o text|json|yaml|table|csv]\n", os.Args[0])
+	}
 
-	if len(flag.Args()) < 2 {
-		log.Fatalf("Usage: %s <production-yaml> <development-yaml>\n", os.Args[0])
+	if !validOutput(output) {
+		log.Fatalf("Unknown output format %q: must be one of text, json, yaml, table, csv\n", output)
 	}
 
-	prodFile := flag.Arg(0)
-	devFile := flag.Arg(1)
+	if *baseline == "" {
+		envs := make([]string, 0, len(files))
+		for env := range files {
+			envs = append(envs, env)
+		}
+		sort.Strings(envs)
+		*baseline = envs[0]
+	} else if _, ok := files[*baseline]; !ok {
+		log.Fatalf("--baseline %q does not match any --file environment\n", *baseline)
+	}
 
-	prodConfig, err := parseYAML(prodFile)
-	if err != nil {
-		log.Fatalf("Error reading production YAML: %v\n", err)
+	configs := make(map[string]*jobdiff.Config, len(files))
+	for env, path := range files {
+		cfg, err := jobdiff.ParseYAML(path)
+		if err != nil {
+			log.Fatalf("Error reading %s YAML (%s): %v\n", env, path, err)
+		}
+		configs[env] = cfg
 	}
 
-	devConfig, err := parseYAML(devFile)
-	if err != nil {
-		log.Fatalf("Error reading development YAML: %v\n", err)
+	var fields []string
+	if *ignoreFields != "" {
+		fields = strings.Split(*ignoreFields, ",")
 	}
 
-	compareCommands(prodFile, devFile, prodConfig, devConfig, *jsonOutput)
-}
\ No newline at end of file
+	compareCommands(configs, *baseline, output, jobdiff.Options{StrictSchedule: *strictSchedule}, fields)
+}
+
+func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "watch":
+			runWatch(os.Args[2:])
+			return
+		case "history":
+			runHistory(os.Args[2:])
+			return
+		}
+	}
+
+	runCompare(os.Args[1:])
+}
@@ -0,0 +1,87 @@
+// Package compare is the embeddable comparison engine behind the CLI's job
+// comparison: it compares the CronJobs of any number of named Configs using
+// github.com/google/go-cmp, with a set of field-aware cmp.Options that other
+// Go programs can reuse or extend.
+package compare
+
+import (
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/jakubfrieb/go-utils-compare/internal/cronsem"
+	"github.com/jakubfrieb/go-utils-compare/internal/jobdiff"
+)
+
+// Comparator computes JobDifferences across any number of named Configs
+// using a configurable set of go-cmp options applied to each CronJob
+// comparison.
+type Comparator struct {
+	// Options is applied by cmp.Equal when deciding whether two CronJobs
+	// are equal at all, e.g. NormalizeWhitespace() or IgnoreFields(...).
+	Options []cmp.Option
+
+	// CanonicalSchedule, when true, treats schedules that merely differ
+	// textually (see internal/cronsem) as informational rather than a
+	// genuine Schedule Difference.
+	CanonicalSchedule bool
+}
+
+// New creates a Comparator that applies opts when deciding whether two
+// CronJobs are equal.
+func New(opts ...cmp.Option) *Comparator {
+	return &Comparator{Options: opts}
+}
+
+// Compare returns every difference between the cron jobs defined across
+// configs (keyed by environment name), diffing every non-baseline
+// environment against baseline (or, for a job missing from baseline, against
+// the lexicographically first environment that has it). It reuses
+// jobdiff.CompareWith for the underlying classification, applying
+// c.Options via cmp.Equal for the Command and Schedule equality checks.
+func (c *Comparator) Compare(configs map[string]*jobdiff.Config, baseline string) []jobdiff.JobDifference {
+	commandEqual := func(a, b string) bool {
+		return cmp.Equal(jobdiff.CronJob{Command: a}, jobdiff.CronJob{Command: b}, c.Options...)
+	}
+	scheduleEqual := func(a, b string) bool {
+		return cmp.Equal(jobdiff.CronJob{Schedule: a}, jobdiff.CronJob{Schedule: b}, c.Options...)
+	}
+	return jobdiff.CompareWith(configs, baseline, c.CanonicalSchedule, commandEqual, scheduleEqual)
+}
+
+// IgnoreFields excludes the named CronJob fields (e.g. "Name", "Schedule")
+// from comparison entirely.
+func IgnoreFields(fields ...string) cmp.Option {
+	if len(fields) == 0 {
+		return cmp.Options{}
+	}
+	return cmpopts.IgnoreFields(jobdiff.CronJob{}, fields...)
+}
+
+// NormalizeWhitespace collapses repeated whitespace in CronJob.Command
+// before comparison, so cosmetic formatting differences are not reported as
+// diffs.
+func NormalizeWhitespace() cmp.Option {
+	return cmp.FilterPath(isStructField("Command"), cmp.Comparer(func(a, b string) bool {
+		return jobdiff.NormalizeCommand(a) == jobdiff.NormalizeCommand(b)
+	}))
+}
+
+// CanonicalizeSchedule treats two CronJob.Schedule cron expressions as equal
+// when they fire at identical times, even if their raw strings differ (see
+// internal/cronsem). It is provided for embedders who want plain cmp.Equal
+// semantics; Comparator itself applies schedule canonicalization via its
+// CanonicalSchedule field instead, so it can still report textual-only
+// schedule differences rather than hiding them.
+func CanonicalizeSchedule() cmp.Option {
+	return cmp.FilterPath(isStructField("Schedule"), cmp.Comparer(func(a, b string) bool {
+		return cronsem.Equivalent(a, b)
+	}))
+}
+
+func isStructField(name string) func(cmp.Path) bool {
+	return func(p cmp.Path) bool {
+		step := p.Last()
+		sf, ok := step.(cmp.StructField)
+		return ok && sf.Name() == name
+	}
+}
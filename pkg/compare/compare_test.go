@@ -0,0 +1,57 @@
+package compare
+
+import (
+	"testing"
+
+	"github.com/jakubfrieb/go-utils-compare/internal/jobdiff"
+)
+
+func TestComparatorNormalizeWhitespace(t *testing.T) {
+	configs := map[string]*jobdiff.Config{
+		"prod": {CronJobs: []jobdiff.CronJob{{Name: "backup", Command: "run  backup", Schedule: "0 0 * * *"}}},
+		"dev":  {CronJobs: []jobdiff.CronJob{{Name: "backup", Command: "run backup", Schedule: "0 0 * * *"}}},
+	}
+
+	diffs := New(NormalizeWhitespace()).Compare(configs, "prod")
+	if len(diffs) != 0 {
+		t.Fatalf("expected no differences after whitespace normalization, got %v", diffs)
+	}
+}
+
+func TestComparatorDetectsCommandDifference(t *testing.T) {
+	configs := map[string]*jobdiff.Config{
+		"prod": {CronJobs: []jobdiff.CronJob{{Name: "backup", Command: "run backup", Schedule: "0 0 * * *"}}},
+		"dev":  {CronJobs: []jobdiff.CronJob{{Name: "backup", Command: "run backup --verbose", Schedule: "0 0 * * *"}}},
+	}
+
+	diffs := New(NormalizeWhitespace()).Compare(configs, "prod")
+	if len(diffs) != 1 || diffs[0].Type != "Command Difference" {
+		t.Fatalf("expected a single Command Difference, got %v", diffs)
+	}
+}
+
+func TestComparatorCanonicalSchedule(t *testing.T) {
+	configs := map[string]*jobdiff.Config{
+		"prod": {CronJobs: []jobdiff.CronJob{{Name: "backup", Command: "run", Schedule: "*/15 * * * *"}}},
+		"dev":  {CronJobs: []jobdiff.CronJob{{Name: "backup", Command: "run", Schedule: "0,15,30,45 * * * *"}}},
+	}
+
+	c := New()
+	c.CanonicalSchedule = true
+	diffs := c.Compare(configs, "prod")
+	if len(diffs) != 1 || diffs[0].Type != "Schedule Difference (textual only)" {
+		t.Fatalf("expected a textual-only schedule difference, got %v", diffs)
+	}
+}
+
+func TestComparatorIgnoreFields(t *testing.T) {
+	configs := map[string]*jobdiff.Config{
+		"prod": {CronJobs: []jobdiff.CronJob{{Name: "backup", Command: "run", Schedule: "0 0 * * *"}}},
+		"dev":  {CronJobs: []jobdiff.CronJob{{Name: "backup", Command: "run", Schedule: "0 1 * * *"}}},
+	}
+
+	diffs := New(IgnoreFields("Schedule")).Compare(configs, "prod")
+	if len(diffs) != 0 {
+		t.Fatalf("expected ignored Schedule field to suppress differences, got %v", diffs)
+	}
+}
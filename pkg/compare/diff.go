@@ -0,0 +1,12 @@
+package compare
+
+import "github.com/jakubfrieb/go-utils-compare/internal/jobdiff"
+
+// UnifiedCommandDiff renders a unified diff (context lines with +/-
+// markers) between two commands, for long multi-line commands where
+// dumping both full strings side by side is hard to read. See
+// jobdiff.UnifiedCommandDiff for the underlying implementation, which this
+// package and internal/jobdiff share.
+func UnifiedCommandDiff(prodCommand, devCommand string) string {
+	return jobdiff.UnifiedCommandDiff(prodCommand, devCommand)
+}
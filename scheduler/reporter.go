@@ -0,0 +1,88 @@
+package scheduler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/jakubfrieb/go-utils-compare/internal/jobdiff"
+)
+
+// Reporter emits a drift report produced by a single Watcher run.
+type Reporter interface {
+	Report(diffs []jobdiff.JobDifference) error
+}
+
+// StdoutReporter writes a one-line-per-difference summary to an io.Writer,
+// typically os.Stdout.
+type StdoutReporter struct {
+	Out io.Writer
+}
+
+func (r StdoutReporter) Report(diffs []jobdiff.JobDifference) error {
+	if len(diffs) == 0 {
+		_, err := fmt.Fprintf(r.Out, "[%s] no drift detected\n", time.Now().Format(time.RFC3339))
+		return err
+	}
+	for _, d := range diffs {
+		if _, err := fmt.Fprintf(r.Out, "[%s] %s: %s (prod=%q dev=%q)\n", time.Now().Format(time.RFC3339), d.CronName, d.Type, d.Values["production"], d.Values["development"]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FileReporter appends a JSON-encoded run record to a log file.
+type FileReporter struct {
+	Writer io.Writer
+}
+
+func (r FileReporter) Report(diffs []jobdiff.JobDifference) error {
+	record := struct {
+		Timestamp time.Time               `json:"timestamp"`
+		Diffs     []jobdiff.JobDifference `json:"diffs"`
+	}{
+		Timestamp: time.Now(),
+		Diffs:     diffs,
+	}
+
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	_, err = r.Writer.Write(data)
+	return err
+}
+
+// WebhookReporter POSTs the diffs as a JSON body to a webhook URL.
+type WebhookReporter struct {
+	URL    string
+	Client *http.Client
+}
+
+func (r WebhookReporter) Report(diffs []jobdiff.JobDifference) error {
+	body, err := json.Marshal(diffs)
+	if err != nil {
+		return err
+	}
+
+	client := r.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Post(r.URL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook %s returned status %d", r.URL, resp.StatusCode)
+	}
+	return nil
+}
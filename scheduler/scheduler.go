@@ -0,0 +1,220 @@
+// Package scheduler turns the one-shot manifest comparison into a
+// long-running drift monitor: it re-runs the comparison on a cron schedule
+// and/or whenever the watched manifests change on disk, and reports the
+// resulting diffs to one or more Reporters.
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/robfig/cron/v3"
+
+	"github.com/jakubfrieb/go-utils-compare/internal/jobdiff"
+)
+
+// ErrNewDriftDetected is returned by Run when FailOnNewDrift is set and a
+// run surfaces a difference that was not present in the previous run.
+var ErrNewDriftDetected = errors.New("scheduler: new drift detected")
+
+// Watcher repeatedly compares ProdPath against DevPath and reports the
+// resulting differences to Reporters.
+type Watcher struct {
+	ProdPath string
+	DevPath  string
+
+	// CronSchedule, if non-empty, is a standard five-field cron expression
+	// (or a @every/@hourly-style descriptor) that triggers a comparison run.
+	CronSchedule string
+
+	// WatchFiles enables fsnotify-based triggering whenever ProdPath or
+	// DevPath (or a manifest inside them, if they are directories) changes.
+	WatchFiles bool
+
+	Reporters []Reporter
+
+	// FailOnNewDrift causes Run to return ErrNewDriftDetected as soon as a
+	// run finds a difference that was absent from the previous run, rather
+	// than continuing to watch indefinitely. This suits CI sidecars that
+	// should fail the build the moment drift first appears.
+	FailOnNewDrift bool
+
+	// DiffOptions is forwarded to jobdiff.FindDifferences on every run.
+	DiffOptions jobdiff.Options
+
+	seen map[string]bool
+}
+
+// New creates a Watcher comparing prodPath against devPath.
+func New(prodPath, devPath string) *Watcher {
+	return &Watcher{
+		ProdPath: prodPath,
+		DevPath:  devPath,
+	}
+}
+
+// Run blocks, triggering comparisons on the configured cron schedule and/or
+// filesystem events, until ctx is cancelled or a fatal error occurs. It
+// always performs one comparison immediately on entry.
+func (w *Watcher) Run(ctx context.Context) error {
+	if err := w.evaluate(); err != nil {
+		return err
+	}
+
+	trigger := make(chan struct{}, 1)
+	notify := func() {
+		select {
+		case trigger <- struct{}{}:
+		default:
+		}
+	}
+
+	if w.CronSchedule != "" {
+		c := cron.New()
+		if _, err := c.AddFunc(w.CronSchedule, notify); err != nil {
+			return err
+		}
+		c.Start()
+		defer c.Stop()
+	}
+
+	if w.WatchFiles {
+		fsw, err := fsnotify.NewWatcher()
+		if err != nil {
+			return err
+		}
+		defer fsw.Close()
+
+		for _, path := range []string{w.ProdPath, w.DevPath} {
+			if err := addWatch(fsw, path); err != nil {
+				return err
+			}
+		}
+
+		go func() {
+			for {
+				select {
+				case ev, ok := <-fsw.Events:
+					if !ok {
+						return
+					}
+					if ev.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename) != 0 {
+						notify()
+					}
+				case _, ok := <-fsw.Errors:
+					if !ok {
+						return
+					}
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-trigger:
+			if err := w.evaluate(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// evaluate performs a single comparison run and reports the result.
+func (w *Watcher) evaluate() error {
+	prodConfig, err := loadConfig(w.ProdPath)
+	if err != nil {
+		return err
+	}
+	devConfig, err := loadConfig(w.DevPath)
+	if err != nil {
+		return err
+	}
+
+	diffs := jobdiff.FindDifferences(map[string]*jobdiff.Config{
+		"production":  prodConfig,
+		"development": devConfig,
+	}, "production", w.DiffOptions)
+
+	for _, r := range w.Reporters {
+		if err := r.Report(diffs); err != nil {
+			return err
+		}
+	}
+
+	if w.FailOnNewDrift && w.newDrift(diffs) {
+		return ErrNewDriftDetected
+	}
+	return nil
+}
+
+// newDrift reports whether diffs contains an entry not present in the
+// previous run, updating the watcher's seen-set as a side effect.
+func (w *Watcher) newDrift(diffs []jobdiff.JobDifference) bool {
+	next := make(map[string]bool, len(diffs))
+	found := false
+	for _, d := range diffs {
+		key := d.CronName + "|" + d.Type
+		for _, env := range []string{"production", "development"} {
+			key += "|" + d.Values[env]
+		}
+		next[key] = true
+		if !w.seen[key] {
+			found = true
+		}
+	}
+	w.seen = next
+	return found
+}
+
+// loadConfig reads a single manifest file, or merges every *.yaml/*.yml file
+// in a directory of manifests into one Config.
+func loadConfig(path string) (*jobdiff.Config, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if !info.IsDir() {
+		return jobdiff.ParseYAML(path)
+	}
+
+	merged := &jobdiff.Config{}
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		ext := filepath.Ext(entry.Name())
+		if ext != ".yaml" && ext != ".yml" {
+			continue
+		}
+		cfg, err := jobdiff.ParseYAML(filepath.Join(path, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		merged.CronJobs = append(merged.CronJobs, cfg.CronJobs...)
+	}
+	return merged, nil
+}
+
+func addWatch(watcher *fsnotify.Watcher, path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if info.IsDir() {
+		return watcher.Add(path)
+	}
+	return watcher.Add(filepath.Dir(path))
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/jakubfrieb/go-utils-compare/internal/jobdiff"
+	"github.com/jakubfrieb/go-utils-compare/scheduler"
+)
+
+func runWatch(args []string) {
+	fs := flag.NewFlagSet("watch", flag.ExitOnError)
+	cronSchedule := fs.String("cron", "", "Cron expression (e.g. \"*/5 * * * *\") on which to re-run the comparison")
+	fsWatch := fs.Bool("fs-watch", true, "Re-run the comparison whenever the watched manifests change on disk")
+	logFile := fs.String("log-file", "", "Append a JSON record of each run's diffs to this file")
+	webhook := fs.String("webhook", "", "POST each run's diffs as JSON to this URL")
+	failOnNewDrift := fs.Bool("fail-on-new-drift", false, "Exit non-zero as soon as a run finds drift that was not present in the previous run")
+	strictSchedule := fs.Bool("strict-schedule", false, "Compare schedules as raw strings instead of semantic cron equivalence")
+	fs.Parse(args)
+
+	if fs.NArg() < 2 {
+		log.Fatalf("Usage: %s watch [flags] <production-path> <development-path>\n", os.Args[0])
+	}
+
+	w := scheduler.New(fs.Arg(0), fs.Arg(1))
+	w.CronSchedule = *cronSchedule
+	w.WatchFiles = *fsWatch
+	w.FailOnNewDrift = *failOnNewDrift
+	w.DiffOptions = jobdiff.Options{StrictSchedule: *strictSchedule}
+
+	w.Reporters = append(w.Reporters, scheduler.StdoutReporter{Out: os.Stdout})
+
+	if *logFile != "" {
+		f, err := os.OpenFile(*logFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+		if err != nil {
+			log.Fatalf("Error opening log file: %v\n", err)
+		}
+		defer f.Close()
+		w.Reporters = append(w.Reporters, scheduler.FileReporter{Writer: f})
+	}
+
+	if *webhook != "" {
+		w.Reporters = append(w.Reporters, scheduler.WebhookReporter{URL: *webhook})
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := w.Run(ctx); err != nil {
+		if err == scheduler.ErrNewDriftDetected {
+			log.Println("new drift detected, exiting")
+			os.Exit(1)
+		}
+		log.Fatalf("watch error: %v\n", err)
+	}
+}